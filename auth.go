@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/MishraShardendu22/Go-TransferScript/pkg/transfer"
+	"golang.org/x/oauth2"
+)
+
+// resolveAuthProvider builds the transfer.AuthProvider selected by
+// config.Auth.Mode, reading whatever secrets that mode needs from the
+// environment. Unset Mode defaults to "pat", reading GITHUB_TOKEN_CLASSIC as
+// a static token.
+func resolveAuthProvider(config *Config) (transfer.AuthProvider, error) {
+	switch config.Auth.Mode {
+	case "", "pat":
+		token := os.Getenv("GITHUB_TOKEN_CLASSIC")
+		if token == "" {
+			return nil, fmt.Errorf("auth mode %q requires GITHUB_TOKEN_CLASSIC to be set", config.Auth.Mode)
+		}
+		return transfer.NewStaticTokenProvider(token), nil
+
+	case "oauth2":
+		clientID := os.Getenv("GITHUB_OAUTH_CLIENT_ID")
+		clientSecret := os.Getenv("GITHUB_OAUTH_CLIENT_SECRET")
+		refreshToken := os.Getenv("GITHUB_OAUTH_REFRESH_TOKEN")
+		if clientID == "" || clientSecret == "" || refreshToken == "" {
+			return nil, fmt.Errorf("auth mode \"oauth2\" requires GITHUB_OAUTH_CLIENT_ID, GITHUB_OAUTH_CLIENT_SECRET, and GITHUB_OAUTH_REFRESH_TOKEN to be set")
+		}
+		oauthConfig := &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Endpoint: oauth2.Endpoint{
+				TokenURL: "https://github.com/login/oauth/access_token",
+			},
+		}
+		source := oauthConfig.TokenSource(context.Background(), &oauth2.Token{RefreshToken: refreshToken})
+		return transfer.NewOAuth2TokenProvider(source), nil
+
+	case "githubApp":
+		if config.Auth.GitHubApp == nil {
+			return nil, fmt.Errorf("auth mode \"githubApp\" requires an auth.githubApp config block")
+		}
+		app := config.Auth.GitHubApp
+		if app.AppID == "" || app.InstallationID == "" || app.PrivateKeyPath == "" {
+			return nil, fmt.Errorf("auth.githubApp requires appID, installationID, and privateKeyPath")
+		}
+		keyPEM, err := os.ReadFile(app.PrivateKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read GitHub App private key from %s: %w", app.PrivateKeyPath, err)
+		}
+		return transfer.NewGitHubAppProvider(config.APIBaseURL, app.AppID, app.InstallationID, keyPEM, config.InsecureSkipTLSVerify)
+
+	default:
+		return nil, fmt.Errorf("unknown auth.mode %q (expected \"pat\", \"oauth2\", or \"githubApp\")", config.Auth.Mode)
+	}
+}
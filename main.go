@@ -1,13 +1,17 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
-	"sync"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/MishraShardendu22/Go-TransferScript/pkg/transfer"
 	"github.com/go-resty/resty/v2"
 	"github.com/joho/godotenv"
 	log "github.com/sirupsen/logrus"
@@ -15,15 +19,67 @@ import (
 
 // Config holds the configuration loaded from config.json
 type Config struct {
-	OriginalUser string   `json:"originalUser"`
-	NewUser      string   `json:"newUser"`
-	Repositories []string `json:"repositories"`
+	OriginalUser string                      `json:"originalUser"`
+	NewUser      string                      `json:"newUser"`
+	Repositories []transfer.RepositoryConfig `json:"repositories"`
+	// APIBaseURL optionally points at a GitHub Enterprise Server instance
+	// (e.g. "https://github.example.com/api/v3"). Defaults to transfer.DefaultAPIBaseURL.
+	APIBaseURL string `json:"apiBaseURL,omitempty"`
+	// InsecureSkipTLSVerify disables TLS certificate verification, for GHES
+	// installations that use a private CA the host doesn't trust. Off by default.
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+	// Auth selects how requests are authenticated. Defaults to a static PAT
+	// read from GITHUB_TOKEN_CLASSIC when left unset.
+	Auth AuthConfig `json:"auth,omitempty"`
+	// Workers is the number of concurrent transfer workers. Defaults to 5.
+	Workers int `json:"workers,omitempty"`
+	// PerRepoTimeoutSeconds bounds each individual transfer request. Zero means no timeout.
+	PerRepoTimeoutSeconds int `json:"perRepoTimeoutSeconds,omitempty"`
+	// GlobalDeadlineSeconds bounds the entire batch run. Zero means no deadline.
+	GlobalDeadlineSeconds int `json:"globalDeadlineSeconds,omitempty"`
+	// WaitForCompletion polls each transferred repo's destination until it
+	// becomes reachable, catching transfers GitHub accepted but never
+	// actually completed (e.g. an org policy rejected it asynchronously).
+	WaitForCompletion bool `json:"waitForCompletion,omitempty"`
+	// PollTimeoutSeconds bounds how long to wait for a transferred repo to
+	// become reachable. Defaults to 2 minutes when WaitForCompletion is set
+	// and this is left zero.
+	PollTimeoutSeconds int `json:"pollTimeoutSeconds,omitempty"`
+	// Report selects how results are rendered once the batch completes.
+	Report ReportConfig `json:"report,omitempty"`
 }
 
+// AuthConfig selects and configures the transfer.AuthProvider used for API requests.
+type AuthConfig struct {
+	// Mode is one of "pat" (default), "oauth2", or "githubApp".
+	Mode string `json:"mode,omitempty"`
+	// GitHubApp configures Mode: "githubApp".
+	GitHubApp *GitHubAppConfig `json:"githubApp,omitempty"`
+}
+
+// GitHubAppConfig configures authentication as a GitHub App installation.
+type GitHubAppConfig struct {
+	AppID          string `json:"appID"`
+	InstallationID string `json:"installationID"`
+	// PrivateKeyPath is the path to the app's PEM-encoded RSA private key.
+	PrivateKeyPath string `json:"privateKeyPath"`
+}
+
+// ReportConfig selects the Reporter used to render the final batch results.
+type ReportConfig struct {
+	// Format is one of "text" (default), "json", or "junit".
+	Format string `json:"format,omitempty"`
+	// Path is where the report is written. Defaults to stdout when empty.
+	Path string `json:"path,omitempty"`
+}
+
+// defaultWorkers is used when Config.Workers is left unset.
+const defaultWorkers = 5
+
 // Initialize global logger
 func init() {
 	log.SetFormatter(&log.TextFormatter{
-		FullTimestamp: true,
+		FullTimestamp:   true,
 		TimestampFormat: "2006-01-02 15:04:05",
 	})
 	log.SetOutput(os.Stdout)
@@ -49,79 +105,67 @@ func loadConfig(filePath string) (*Config, error) {
 	if len(config.Repositories) == 0 {
 		return nil, errors.New("repositories list cannot be empty in config")
 	}
-	log.Infof("Configuration loaded successfully from %s. OriginalUser: %s, NewUser: %s, Repositories: %d",
-		filePath, config.OriginalUser, config.NewUser, len(config.Repositories))
+
+	if config.APIBaseURL == "" {
+		config.APIBaseURL = transfer.DefaultAPIBaseURL
+	}
+	if err := transfer.ValidateAPIBaseURL(config.APIBaseURL); err != nil {
+		return nil, fmt.Errorf("invalid apiBaseURL in config: %w", err)
+	}
+	if config.Workers <= 0 {
+		config.Workers = defaultWorkers
+	}
+
+	log.Infof("Configuration loaded successfully from %s. OriginalUser: %s, NewUser: %s, Repositories: %d, APIBaseURL: %s",
+		filePath, config.OriginalUser, config.NewUser, len(config.Repositories), config.APIBaseURL)
 	return &config, nil
 }
 
-// transferRepo attempts to transfer a GitHub repository and returns an error if the transfer fails.
-// repoName is used for logging purposes.
-func transferRepo(client *resty.Client, transferURL string, newUser string, repoName string, githubToken string) error {
-	log.WithFields(log.Fields{
-		"repo":    repoName,
-		"newUser": newUser,
-		"url":     transferURL,
-	}).Info("Attempting to transfer repository")
-
-	res, err := client.R().
-		SetBody(map[string]string{
-			"new_owner": newUser,
-			"new_name":  repoName,
-		}).
-		SetHeader("Accept", "application/vnd.github+json").
-		SetHeader("Authorization", "Bearer "+githubToken).
-		SetHeader("X-GitHub-Api-Version", "2022-11-28").
-		Post(transferURL)
+// buildJobs converts the flat repository list from config.json into the
+// transfer.TransferJob batch the Transferer expects.
+func buildJobs(config *Config) []transfer.TransferJob {
+	jobs := make([]transfer.TransferJob, 0, len(config.Repositories))
+	for _, repo := range config.Repositories {
+		jobs = append(jobs, transfer.TransferJob{
+			Owner:    config.OriginalUser,
+			Repo:     repo.Source,
+			NewOwner: config.NewUser,
+			NewName:  repo.Destination,
+			TeamIDs:  repo.TeamIDs,
+		})
+	}
+	return jobs
+}
 
-	if err != nil {
-		// This error is from the client (e.g., network issue)
-		return fmt.Errorf("client request for repo %s failed: %w", repoName, err)
-	}
-
-	logFields := log.Fields{
-		"repo":       repoName,
-		"statusCode": res.StatusCode(),
-		"status":     res.Status(),
-	}
-	if res.StatusCode() != 202 { // Log body for non-successful transfers for debugging
-		logFields["responseBody"] = res.String()
-	}
-
-
-	switch res.StatusCode() {
-	case 202: // Accepted
-		log.WithFields(logFields).Info("Repository transfer successful")
-		return nil
-	case 401: // Unauthorized
-		log.WithFields(logFields).Error("Repository transfer failed: Unauthorized")
-		return fmt.Errorf("repo %s: Unauthorized (HTTP %d). Check GitHub token and permissions. Response: %s", repoName, res.StatusCode(), res.String())
-	case 403: // Forbidden
-		log.WithFields(logFields).Error("Repository transfer failed: Forbidden")
-		return fmt.Errorf("repo %s: Forbidden (HTTP %d). API rate limits or insufficient permissions. Response: %s", repoName, res.StatusCode(), res.String())
-	case 404: // Not Found
-		log.WithFields(logFields).Error("Repository transfer failed: Not Found")
-		return fmt.Errorf("repo %s: Repository or user not found (HTTP %d). Response: %s", repoName, res.StatusCode(), res.String())
-	case 422: // Unprocessable Entity
-		log.WithFields(logFields).Error("Repository transfer failed: Unprocessable Entity")
-		return fmt.Errorf("repo %s: Unprocessable Entity (HTTP %d). Semantic errors. Response: %s", repoName, res.StatusCode(), res.String())
+// selectReporter returns the transfer.Reporter named by format, defaulting to
+// TextReporter for an empty or unrecognized value.
+func selectReporter(format string) transfer.Reporter {
+	switch format {
+	case "json":
+		return transfer.JSONLReporter{}
+	case "junit":
+		return transfer.JUnitReporter{}
 	default:
-		log.WithFields(logFields).Error("Repository transfer failed: Unexpected status code")
-		return fmt.Errorf("repo %s: Unexpected status code (HTTP %d). Response: %s", repoName, res.StatusCode(), res.String())
+		return transfer.TextReporter{}
+	}
+}
+
+// openReportWriter opens the destination for the final report, defaulting to
+// stdout when path is empty.
+func openReportWriter(path string) (*os.File, error) {
+	if path == "" {
+		return os.Stdout, nil
 	}
+	return os.Create(path)
 }
 
 func main() {
 	log.Info("Initializing GitHub Repository Transfer Script")
 
-	// Load .env file for GitHub token
+	// Load .env file for auth secrets (GITHUB_TOKEN_CLASSIC, OAuth2 client credentials, etc.)
 	if err := godotenv.Load(); err != nil {
 		log.Fatalf("Fatal error: Failed to load .env file: %v", err)
 	}
-	githubToken := os.Getenv("GITHUB_TOKEN_CLASSIC")
-	if githubToken == "" {
-		log.Fatal("Fatal error: GITHUB_TOKEN_CLASSIC environment variable is not set.")
-	}
-	log.Info("GITHUB_TOKEN_CLASSIC loaded successfully.")
 
 	// Load configuration from config.json
 	appConfig, err := loadConfig("config.json")
@@ -129,78 +173,68 @@ func main() {
 		log.Fatalf("Fatal error: Failed to load configuration from config.json: %v", err)
 	}
 
-	originalUser := appConfig.OriginalUser
-	newUser := appConfig.NewUser
-	allRepos := appConfig.Repositories
+	log.Infof("Processing %d repositories for transfer from %s to %s.",
+		len(appConfig.Repositories), appConfig.OriginalUser, appConfig.NewUser)
 
-	if len(allRepos) == 0 {
-		log.Info("No repositories listed in config.json. Exiting application.")
-		return
+	client := transfer.ConfigureRetryPolicy(resty.New().SetCloseConnection(true))
+	if appConfig.InsecureSkipTLSVerify {
+		log.Warn("TLS certificate verification is disabled (insecureSkipTLSVerify=true). Only use this for trusted GitHub Enterprise Server instances with private CAs.")
+		client.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true})
 	}
-	log.Infof("Processing %d repositories for transfer from %s to %s.", len(allRepos), originalUser, newUser)
-
-	client := resty.New().
-		SetRetryCount(3).
-		SetRetryWaitTime(2 * time.Second).
-		SetRetryMaxWaitTime(10 * time.Second).
-		SetCloseConnection(true)
 
-	var wg sync.WaitGroup
-	numWorkers := 5 // This could be made configurable
-
-	jobs := make(chan string, len(allRepos))
-	results := make(chan string, len(allRepos))
-
-	log.Infof("Starting %d worker goroutines.", numWorkers)
-	for w := 1; w <= numWorkers; w++ {
-		wg.Add(1)
-		go func(workerID int, oUser, nUser string) {
-			defer wg.Done()
-			workerLog := log.WithFields(log.Fields{"workerID": workerID})
-			workerLog.Infof("Worker started. Transferring from %s to %s.", oUser, nUser)
-
-			for repoName := range jobs {
-				repoLog := workerLog.WithField("repo", repoName)
-				repoLog.Info("Processing repository transfer")
+	authProvider, err := resolveAuthProvider(appConfig)
+	if err != nil {
+		log.Fatalf("Fatal error: Failed to set up authentication: %v", err)
+	}
+	transfer.AuthInjectingClient(client, authProvider)
+	log.Infof("Authentication mode %q configured successfully.", appConfig.Auth.Mode)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if appConfig.GlobalDeadlineSeconds > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(appConfig.GlobalDeadlineSeconds)*time.Second)
+		defer cancel()
+	}
 
-				transferAPIURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/transfer", oUser, repoName)
-				err := transferRepo(client, transferAPIURL, nUser, repoName, githubToken)
-				if err != nil {
-					repoLog.WithError(err).Error("Repository transfer attempt failed")
-					results <- fmt.Sprintf("[FAIL] Worker %d, Repo %s: %v", workerID, repoName, err)
-				} else {
-					repoLog.Info("Repository transfer attempt successful")
-					results <- fmt.Sprintf("[SUCCESS] Worker %d, Repo %s: Transfer successful", workerID, repoName)
-				}
-			}
-			workerLog.Info("Worker finished.")
-		}(w, originalUser, newUser)
+	transferer := &transfer.Transferer{
+		Client:            client,
+		AuthProvider:      authProvider,
+		APIBaseURL:        appConfig.APIBaseURL,
+		Workers:           appConfig.Workers,
+		PerRepoTimeout:    time.Duration(appConfig.PerRepoTimeoutSeconds) * time.Second,
+		WaitForCompletion: appConfig.WaitForCompletion,
+		PollTimeout:       time.Duration(appConfig.PollTimeoutSeconds) * time.Second,
 	}
 
-	log.Infof("Distributing %d repository transfer jobs to workers.", len(allRepos))
-	for _, repo := range allRepos {
-		jobs <- repo
+	jobs := buildJobs(appConfig)
+
+	log.Info("Running pre-flight checks (repository existence, token scopes)...")
+	if err := transferer.Preflight(ctx, jobs); err != nil {
+		log.Fatalf("Fatal error: pre-flight checks failed: %v", err)
 	}
-	close(jobs)
-	log.Info("All jobs dispatched. Waiting for workers to complete.")
 
-	wg.Wait()
-	close(results)
+	log.Infof("Starting %d worker goroutines.", transferer.Workers)
+	results := transferer.Run(ctx, jobs)
 	log.Info("All workers have completed.")
 
-	log.Info("--- Final Transfer Summary ---")
-	successCount := 0
-	failureCount := 0
-	for resMsg := range results {
-		// Log the raw message from channel for now, could be more structured
-		log.Debugf("Raw result message: %s", resMsg)
-		if _, parseErr := fmt.Sscanf(resMsg, "[SUCCESS]%s", new(string)); parseErr == nil {
-			successCount++
-		} else {
-			failureCount++
+	if lines := transfer.ReconciliationSummary(results); len(lines) > 0 {
+		log.Warn("Reconciliation needed: the following repos were accepted for transfer but never became reachable at their destination:")
+		for _, line := range lines {
+			log.Warn(line)
 		}
 	}
 
-	log.Infof("Script execution finished. Total Repositories: %d, Successes: %d, Failures: %d",
-		len(allRepos), successCount, failureCount)
+	reportWriter, err := openReportWriter(appConfig.Report.Path)
+	if err != nil {
+		log.Fatalf("Fatal error: Failed to open report destination %s: %v", appConfig.Report.Path, err)
+	}
+	if reportWriter != os.Stdout {
+		defer reportWriter.Close()
+	}
+
+	reporter := selectReporter(appConfig.Report.Format)
+	if err := reporter.Report(reportWriter, results); err != nil {
+		log.Fatalf("Fatal error: Failed to write transfer report: %v", err)
+	}
 }
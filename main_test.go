@@ -1,171 +1,131 @@
 package main
 
 import (
-	"fmt"
-	"net/http"
-	"net/http/httptest"
-	"strings"
+	"os"
+	"path/filepath"
 	"testing"
 
-	"github.com/go-resty/resty/v2"
+	"github.com/MishraShardendu22/Go-TransferScript/pkg/transfer"
 )
 
-func TestTransferRepo(t *testing.T) {
-	// Helper function to create a new resty client for testing.
-	// Note: SetHostURL is not strictly necessary here anymore since transferRepo takes a full URL,
-	// but it's good practice if other client methods were to be used with relative paths.
-	newTestClient := func() *resty.Client {
-		client := resty.New()
-		return client
+func writeConfigFile(t *testing.T, contents string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
 	}
+	return path
+}
 
-	// Define test cases
+func TestLoadConfig(t *testing.T) {
 	tests := []struct {
-		name            string
-		serverHandler   http.HandlerFunc // Simulates GitHub API server responses
-		newUser         string
-		repoName        string // Name of the repo for transfer body and logging
-		githubToken     string
-		expectError     bool
-		expectedErrorMsgSubstring string // If expectError is true, check if the error message contains this
-		// originalUser and repo for URL construction are now part of how transferURL is built per test case
-		urlOriginalUser string // For constructing the test URL path
-		urlRepo         string // For constructing the test URL path
+		name        string
+		contents    string
+		expectError bool
+		checkConfig func(t *testing.T, config *Config)
 	}{
 		{
-			name: "Successful transfer",
-			serverHandler: func(w http.ResponseWriter, r *http.Request) {
-				if r.Method != http.MethodPost {
-					t.Errorf("Expected POST request, got %s", r.Method)
-				}
-				if auth := r.Header.Get("Authorization"); auth != "Bearer test-token" {
-					t.Errorf("Expected Authorization header 'Bearer test-token', got '%s'", auth)
+			name:     "minimal valid config fills in defaults",
+			contents: `{"originalUser": "alice", "newUser": "bob", "repositories": ["repo-one"]}`,
+			checkConfig: func(t *testing.T, config *Config) {
+				if config.APIBaseURL != transfer.DefaultAPIBaseURL {
+					t.Errorf("APIBaseURL = %q, want %q", config.APIBaseURL, transfer.DefaultAPIBaseURL)
 				}
-				// Path check: /repos/test-orig-user/test-repo/transfer
-				expectedPath := "/repos/test-orig-user/test-repo/transfer"
-				if r.URL.Path != expectedPath {
-					t.Errorf("Expected URL path '%s', got '%s'", expectedPath, r.URL.Path)
+				if config.Workers != defaultWorkers {
+					t.Errorf("Workers = %d, want %d", config.Workers, defaultWorkers)
 				}
-				w.WriteHeader(http.StatusAccepted) // 202
-				fmt.Fprintln(w, `{"message": "Repository transfer initiated"}`)
-			},
-			newUser:         "test-new-user",
-			repoName:        "test-repo",
-			githubToken:     "test-token",
-			expectError:     false,
-			urlOriginalUser: "test-orig-user",
-			urlRepo:         "test-repo",
-		},
-		{
-			name: "Unauthorized - 401",
-			serverHandler: func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(http.StatusUnauthorized) // 401
-				fmt.Fprintln(w, `{"message": "Bad credentials"}`)
 			},
-			newUser:         "test-new-user",
-			repoName:        "test-repo-401",
-			githubToken:     "invalid-token",
-			expectError:     true,
-			expectedErrorMsgSubstring: "Unauthorized (HTTP 401)",
-			urlOriginalUser: "test-orig-user",
-			urlRepo:         "test-repo-401",
 		},
 		{
-			name: "Forbidden - 403",
-			serverHandler: func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(http.StatusForbidden) // 403
-				fmt.Fprintln(w, `{"message": "Rate limit exceeded or insufficient permissions"}`)
-			},
-			newUser:         "test-new-user",
-			repoName:        "test-repo-403",
-			githubToken:     "test-token",
-			expectError:     true,
-			expectedErrorMsgSubstring: "Forbidden (HTTP 403)",
-			urlOriginalUser: "test-orig-user",
-			urlRepo:         "test-repo-403",
+			name:        "missing originalUser is rejected",
+			contents:    `{"newUser": "bob", "repositories": ["repo-one"]}`,
+			expectError: true,
 		},
 		{
-			name: "Not Found - 404",
-			serverHandler: func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(http.StatusNotFound) // 404
-				fmt.Fprintln(w, `{"message": "Repository not found"}`)
-			},
-			newUser:         "test-new-user",
-			repoName:        "non-existent-repo",
-			githubToken:     "test-token",
-			expectError:     true,
-			expectedErrorMsgSubstring: "Repository or user not found (HTTP 404)",
-			urlOriginalUser: "test-orig-user",
-			urlRepo:         "non-existent-repo",
+			name:        "empty repositories list is rejected",
+			contents:    `{"originalUser": "alice", "newUser": "bob", "repositories": []}`,
+			expectError: true,
 		},
 		{
-			name: "Unprocessable Entity - 422",
-			serverHandler: func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(http.StatusUnprocessableEntity) // 422
-				fmt.Fprintln(w, `{"message": "Validation failed"}`)
-			},
-			newUser:         "test-new-user",
-			repoName:        "test-repo-422",
-			githubToken:     "test-token",
-			expectError:     true,
-			expectedErrorMsgSubstring: "Unprocessable Entity (HTTP 422)",
-			urlOriginalUser: "test-orig-user",
-			urlRepo:         "test-repo-422",
+			name:        "malformed apiBaseURL is rejected",
+			contents:    `{"originalUser": "alice", "newUser": "bob", "repositories": ["repo-one"], "apiBaseURL": "not-a-url"}`,
+			expectError: true,
 		},
 		{
-			name: "Unexpected Status Code - 500",
-			serverHandler: func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(http.StatusInternalServerError) // 500
-				fmt.Fprintln(w, `{"message": "Server error"}`)
+			name:     "explicit workers count is preserved",
+			contents: `{"originalUser": "alice", "newUser": "bob", "repositories": ["repo-one"], "workers": 10}`,
+			checkConfig: func(t *testing.T, config *Config) {
+				if config.Workers != 10 {
+					t.Errorf("Workers = %d, want 10", config.Workers)
+				}
 			},
-			newUser:         "test-new-user",
-			repoName:        "test-repo-500",
-			githubToken:     "test-token",
-			expectError:     true,
-			expectedErrorMsgSubstring: "Unexpected status code (HTTP 500)",
-			urlOriginalUser: "test-orig-user",
-			urlRepo:         "test-repo-500",
-		},
-		{
-			name:            "Client request error (e.g. network error)",
-			serverHandler:   nil, // No server needed, client will fail using a bad URL
-			newUser:         "test-new-user",
-			repoName:        "test-repo-network-error",
-			githubToken:     "test-token",
-			expectError:     true,
-			expectedErrorMsgSubstring: "failed to send transfer request",
-			urlOriginalUser: "test-orig-user", // Used to construct the bad URL
-			urlRepo:         "test-repo-network-error",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			client := newTestClient() // Create a new client for each test
-			var transferURL string
-
-			if tc.serverHandler != nil {
-				server := httptest.NewServer(tc.serverHandler)
-				defer server.Close()
-				// Construct the URL to use the test server, mimicking the GitHub API path structure
-				transferURL = fmt.Sprintf("%s/repos/%s/%s/transfer", server.URL, tc.urlOriginalUser, tc.urlRepo)
-			} else {
-				// For client-side error test, use an invalid URL that won't resolve or connect
-				transferURL = fmt.Sprintf("http://.invalidlocaldomain:12345/repos/%s/%s/transfer", tc.urlOriginalUser, tc.urlRepo)
-			}
-
-			err := transferRepo(client, transferURL, tc.newUser, tc.repoName, tc.githubToken)
-
+			path := writeConfigFile(t, tc.contents)
+			config, err := loadConfig(path)
 			if tc.expectError {
 				if err == nil {
-					t.Errorf("Expected an error, but got nil")
-				} else if !strings.Contains(err.Error(), tc.expectedErrorMsgSubstring) {
-					t.Errorf("Expected error message to contain '%s', but got '%s'", tc.expectedErrorMsgSubstring, err.Error())
-				}
-			} else {
-				if err != nil {
-					t.Errorf("Expected no error, but got: %v", err)
+					t.Fatal("expected an error, got nil")
 				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.checkConfig != nil {
+				tc.checkConfig(t, config)
+			}
+		})
+	}
+}
+
+func TestBuildJobs(t *testing.T) {
+	config := &Config{
+		OriginalUser: "alice",
+		NewUser:      "bob",
+		Repositories: []transfer.RepositoryConfig{
+			{Source: "repo-one"},
+			{Source: "repo-two", Destination: "repo-two-renamed", TeamIDs: []int64{42}},
+		},
+	}
+
+	jobs := buildJobs(config)
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d", len(jobs))
+	}
+	for i, repo := range config.Repositories {
+		if jobs[i].Owner != "alice" || jobs[i].Repo != repo.Source || jobs[i].NewOwner != "bob" {
+			t.Errorf("job[%d] = %+v, want Owner=alice Repo=%s NewOwner=bob", i, jobs[i], repo.Source)
+		}
+	}
+	if jobs[1].NewName != "repo-two-renamed" {
+		t.Errorf("jobs[1].NewName = %q, want %q", jobs[1].NewName, "repo-two-renamed")
+	}
+	if len(jobs[1].TeamIDs) != 1 || jobs[1].TeamIDs[0] != 42 {
+		t.Errorf("jobs[1].TeamIDs = %v, want [42]", jobs[1].TeamIDs)
+	}
+}
+
+func TestSelectReporter(t *testing.T) {
+	tests := []struct {
+		format string
+		want   transfer.Reporter
+	}{
+		{format: "", want: transfer.TextReporter{}},
+		{format: "text", want: transfer.TextReporter{}},
+		{format: "json", want: transfer.JSONLReporter{}},
+		{format: "junit", want: transfer.JUnitReporter{}},
+		{format: "unknown", want: transfer.TextReporter{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.format, func(t *testing.T) {
+			if got := selectReporter(tc.format); got != tc.want {
+				t.Errorf("selectReporter(%q) = %T, want %T", tc.format, got, tc.want)
 			}
 		})
 	}
@@ -0,0 +1,185 @@
+package transfer
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/golang-jwt/jwt/v5"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+)
+
+// AuthProvider supplies the bearer token to attach to GitHub API requests.
+// Implementations are responsible for refreshing the token before it expires.
+type AuthProvider interface {
+	// Token returns a valid bearer token, refreshing it first if necessary.
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticTokenProvider wraps a classic personal access token that never expires.
+type StaticTokenProvider struct {
+	token string
+}
+
+// NewStaticTokenProvider returns an AuthProvider backed by a fixed PAT.
+func NewStaticTokenProvider(token string) *StaticTokenProvider {
+	return &StaticTokenProvider{token: token}
+}
+
+// Token implements AuthProvider.
+func (p *StaticTokenProvider) Token(_ context.Context) (string, error) {
+	if p.token == "" {
+		return "", fmt.Errorf("static token provider: token is empty")
+	}
+	return p.token, nil
+}
+
+// OAuth2TokenProvider adapts an oauth2.TokenSource, refreshing automatically
+// when the underlying token is close to expiry.
+type OAuth2TokenProvider struct {
+	source oauth2.TokenSource
+}
+
+// NewOAuth2TokenProvider returns an AuthProvider backed by the given token source.
+// Wrap source in oauth2.ReuseTokenSource upstream if it isn't self-caching.
+func NewOAuth2TokenProvider(source oauth2.TokenSource) *OAuth2TokenProvider {
+	return &OAuth2TokenProvider{source: source}
+}
+
+// Token implements AuthProvider.
+func (p *OAuth2TokenProvider) Token(_ context.Context) (string, error) {
+	tok, err := p.source.Token()
+	if err != nil {
+		return "", fmt.Errorf("oauth2 token provider: failed to obtain token: %w", err)
+	}
+	return tok.AccessToken, nil
+}
+
+// installationTokenResponse is the payload returned by
+// POST /app/installations/{id}/access_tokens.
+type installationTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// GitHubAppProvider authenticates as a GitHub App installation. It mints a
+// short-lived JWT signed with the app's private key, exchanges it for an
+// installation access token, and transparently refreshes that token shortly
+// before it expires.
+//
+// It mints and refreshes that token over a private HTTP client of its own,
+// never the shared client returned by AuthInjectingClient: that client's
+// OnBeforeRequest hook calls back into Token to attach the Authorization
+// header, which would reenter Token's mutex on the same goroutine and
+// deadlock forever.
+type GitHubAppProvider struct {
+	appID          string
+	installationID string
+	privateKey     *rsa.PrivateKey
+	apiBaseURL     string
+	client         *resty.Client
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewGitHubAppProvider returns an AuthProvider that authenticates as the given
+// GitHub App installation. privateKeyPEM is the app's PEM-encoded RSA private
+// key. insecureSkipTLSVerify mirrors the same setting on the caller's shared
+// client, for GHES instances with private CAs.
+func NewGitHubAppProvider(apiBaseURL, appID, installationID string, privateKeyPEM []byte, insecureSkipTLSVerify bool) (*GitHubAppProvider, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("github app provider: failed to parse private key: %w", err)
+	}
+	client := ConfigureRetryPolicy(resty.New())
+	if insecureSkipTLSVerify {
+		client.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true})
+	}
+	return &GitHubAppProvider{
+		appID:          appID,
+		installationID: installationID,
+		privateKey:     key,
+		apiBaseURL:     apiBaseURL,
+		client:         client,
+	}, nil
+}
+
+// installationTokenRefreshSkew is how far ahead of expiry a new installation
+// token is requested, to avoid racing a request against the old token's expiry.
+const installationTokenRefreshSkew = 60 * time.Second
+
+// Token implements AuthProvider, refreshing the installation token when it is
+// missing or within installationTokenRefreshSkew of expiring.
+func (p *GitHubAppProvider) Token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != "" && time.Now().Add(installationTokenRefreshSkew).Before(p.expiresAt) {
+		return p.token, nil
+	}
+
+	appJWT, err := p.signAppJWT()
+	if err != nil {
+		return "", fmt.Errorf("github app provider: failed to sign app JWT: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/app/installations/%s/access_tokens", p.apiBaseURL, p.installationID)
+	res, err := p.client.R().
+		SetContext(ctx).
+		SetHeader("Accept", "application/vnd.github+json").
+		SetHeader("Authorization", "Bearer "+appJWT).
+		SetHeader("X-GitHub-Api-Version", "2022-11-28").
+		Post(url)
+	if err != nil {
+		return "", fmt.Errorf("github app provider: installation token request failed: %w", err)
+	}
+	if res.StatusCode() != 201 {
+		return "", fmt.Errorf("github app provider: installation token request returned HTTP %d: %s", res.StatusCode(), res.String())
+	}
+
+	var parsed installationTokenResponse
+	if err := json.Unmarshal(res.Body(), &parsed); err != nil {
+		return "", fmt.Errorf("github app provider: failed to parse installation token response: %w", err)
+	}
+
+	p.token = parsed.Token
+	p.expiresAt = parsed.ExpiresAt
+	log.WithField("installationID", p.installationID).Info("Refreshed GitHub App installation access token")
+	return p.token, nil
+}
+
+// signAppJWT mints a short-lived JWT identifying the app, per GitHub's
+// app-authentication scheme (RS256, iss = app ID, iat/exp within 10 minutes).
+func (p *GitHubAppProvider) signAppJWT() (string, error) {
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		IssuedAt:  jwt.NewNumericDate(now.Add(-30 * time.Second)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(9 * time.Minute)),
+		Issuer:    p.appID,
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	return token.SignedString(p.privateKey)
+}
+
+// AuthInjectingClient returns a resty client that attaches the AuthProvider's
+// current token as a Bearer Authorization header on every outgoing request,
+// refreshing it transparently. Workers share this single client instance.
+func AuthInjectingClient(client *resty.Client, provider AuthProvider) *resty.Client {
+	client.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		token, err := provider.Token(req.Context())
+		if err != nil {
+			return fmt.Errorf("failed to obtain auth token: %w", err)
+		}
+		req.SetHeader("Authorization", "Bearer "+token)
+		return nil
+	})
+	return client
+}
@@ -0,0 +1,52 @@
+package transfer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// RepositoryConfig describes one repository to transfer. It unmarshals from
+// either a bare string (the source repo name, destination name and owner
+// assumed unchanged) or an object with "source", optional "destination", and
+// optional "teamIDs" fields, matching the shape GitHub's transfer endpoint
+// itself accepts.
+type RepositoryConfig struct {
+	// Source is the repository's current name. Required.
+	Source string
+	// Destination renames the repository during transfer. Empty keeps the
+	// name unchanged.
+	Destination string
+	// TeamIDs grants the listed organization teams access at the
+	// destination, when transferring into an organization.
+	TeamIDs []int64
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either a bare string
+// or an object of the form {"source", "destination", "teamIDs"}.
+func (r *RepositoryConfig) UnmarshalJSON(data []byte) error {
+	var asString string
+	if err := json.Unmarshal(data, &asString); err == nil {
+		r.Source = asString
+		r.Destination = ""
+		r.TeamIDs = nil
+		return nil
+	}
+
+	var asObject struct {
+		Source      string  `json:"source"`
+		Destination string  `json:"destination,omitempty"`
+		TeamIDs     []int64 `json:"teamIDs,omitempty"`
+	}
+	if err := json.Unmarshal(data, &asObject); err != nil {
+		return fmt.Errorf("repository entry must be a string or an object with a \"source\" field: %w", err)
+	}
+	if asObject.Source == "" {
+		return errors.New("repository object entry requires a non-empty \"source\" field")
+	}
+
+	r.Source = asObject.Source
+	r.Destination = asObject.Destination
+	r.TeamIDs = asObject.TeamIDs
+	return nil
+}
@@ -0,0 +1,70 @@
+package transfer
+
+import "testing"
+
+func TestRepositoryConfigUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		json        string
+		expectError bool
+		check       func(t *testing.T, r RepositoryConfig)
+	}{
+		{
+			name: "bare string is treated as source",
+			json: `"my-repo"`,
+			check: func(t *testing.T, r RepositoryConfig) {
+				if r.Source != "my-repo" || r.Destination != "" || r.TeamIDs != nil {
+					t.Errorf("got %+v, want Source=my-repo with no Destination/TeamIDs", r)
+				}
+			},
+		},
+		{
+			name: "object with source only",
+			json: `{"source": "my-repo"}`,
+			check: func(t *testing.T, r RepositoryConfig) {
+				if r.Source != "my-repo" || r.Destination != "" {
+					t.Errorf("got %+v, want Source=my-repo with no Destination", r)
+				}
+			},
+		},
+		{
+			name: "object with destination and teamIDs",
+			json: `{"source": "my-repo", "destination": "renamed-repo", "teamIDs": [1, 2]}`,
+			check: func(t *testing.T, r RepositoryConfig) {
+				if r.Source != "my-repo" || r.Destination != "renamed-repo" {
+					t.Errorf("got %+v, want Source=my-repo Destination=renamed-repo", r)
+				}
+				if len(r.TeamIDs) != 2 || r.TeamIDs[0] != 1 || r.TeamIDs[1] != 2 {
+					t.Errorf("TeamIDs = %v, want [1 2]", r.TeamIDs)
+				}
+			},
+		},
+		{
+			name:        "object missing source is rejected",
+			json:        `{"destination": "renamed-repo"}`,
+			expectError: true,
+		},
+		{
+			name:        "neither string nor object is rejected",
+			json:        `42`,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var r RepositoryConfig
+			err := r.UnmarshalJSON([]byte(tc.json))
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tc.check(t, r)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Scopes required by the transfer endpoint. scopeAdminOrg is only required
+// for jobs that grant team access via TeamIDs.
+const (
+	scopeRepo     = "repo"
+	scopeAdminOrg = "admin:org"
+)
+
+// Preflight verifies, before any transfer is attempted, that every job's
+// source repository exists and the authenticated token carries the scopes
+// GitHub's transfer endpoint requires. Failing fast here avoids burning API
+// calls on transfers that are certain to 422. Errors from individual jobs are
+// joined so a misconfigured batch reports every problem at once.
+func (t *Transferer) Preflight(ctx context.Context, jobs []TransferJob) error {
+	apiBaseURL := t.APIBaseURL
+	if apiBaseURL == "" {
+		apiBaseURL = DefaultAPIBaseURL
+	}
+
+	var errs []error
+	for _, job := range jobs {
+		if err := t.preflightOne(ctx, apiBaseURL, job); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// preflightOne checks a single job's repository and token scopes.
+func (t *Transferer) preflightOne(ctx context.Context, apiBaseURL string, job TransferJob) error {
+	repoURL := fmt.Sprintf("%s/repos/%s/%s", apiBaseURL, job.Owner, job.Repo)
+
+	res, err := t.Client.R().
+		SetContext(ctx).
+		SetHeader("Accept", "application/vnd.github+json").
+		SetHeader("X-GitHub-Api-Version", "2022-11-28").
+		Get(repoURL)
+	if err != nil {
+		return fmt.Errorf("repo %s: preflight check failed: %w", job.Repo, err)
+	}
+
+	if res.StatusCode() == 404 {
+		return fmt.Errorf("repo %s: preflight check failed: repository %s/%s not found (HTTP 404)", job.Repo, job.Owner, job.Repo)
+	}
+	if res.StatusCode() != 200 {
+		return fmt.Errorf("repo %s: preflight check failed: unexpected status fetching %s/%s (HTTP %d). Response: %s",
+			job.Repo, job.Owner, job.Repo, res.StatusCode(), res.String())
+	}
+
+	if _, isGitHubApp := t.AuthProvider.(*GitHubAppProvider); isGitHubApp {
+		// GitHub App installation tokens carry fine-grained repository
+		// permissions, not OAuth scopes, so GitHub never sets X-OAuth-Scopes
+		// on requests they authenticate. The repository-existence check above
+		// is the extent of what preflight can verify for this auth mode.
+		log.WithField("repo", job.Repo).Info("Preflight check passed (scope check skipped for GitHub App auth)")
+		return nil
+	}
+
+	scopes := parseOAuthScopes(res.Header().Get("X-OAuth-Scopes"))
+	if !scopes[scopeRepo] {
+		return fmt.Errorf("repo %s: preflight check failed: token is missing the %q scope required to transfer repositories", job.Repo, scopeRepo)
+	}
+	if len(job.TeamIDs) > 0 && !scopes[scopeAdminOrg] {
+		return fmt.Errorf("repo %s: preflight check failed: transferring with teamIDs requires the %q scope, which the token does not have", job.Repo, scopeAdminOrg)
+	}
+
+	log.WithField("repo", job.Repo).Info("Preflight check passed")
+	return nil
+}
+
+// parseOAuthScopes splits a comma-separated X-OAuth-Scopes header value into
+// a membership set.
+func parseOAuthScopes(header string) map[string]bool {
+	scopes := make(map[string]bool)
+	for _, raw := range strings.Split(header, ",") {
+		scope := strings.TrimSpace(raw)
+		if scope != "" {
+			scopes[scope] = true
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,143 @@
+package transfer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func preflightTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "missing-repo"):
+			w.WriteHeader(http.StatusNotFound)
+		case strings.Contains(r.URL.Path, "no-scope-repo"):
+			w.Header().Set("X-OAuth-Scopes", "read:org")
+			w.WriteHeader(http.StatusOK)
+		case strings.Contains(r.URL.Path, "org-repo"):
+			w.Header().Set("X-OAuth-Scopes", "repo, read:org")
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.Header().Set("X-OAuth-Scopes", "repo, admin:org")
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+}
+
+func TestPreflightChecksRepositoryAndScopes(t *testing.T) {
+	server := preflightTestServer(t)
+	defer server.Close()
+
+	transferer := &Transferer{
+		Client:       AuthInjectingClient(resty.New(), NewStaticTokenProvider("test-token")),
+		AuthProvider: NewStaticTokenProvider("test-token"),
+		APIBaseURL:   server.URL,
+	}
+
+	tests := []struct {
+		name        string
+		job         TransferJob
+		expectError bool
+	}{
+		{name: "existing repo with sufficient scopes", job: TransferJob{Owner: "acme", Repo: "ok-repo"}},
+		{name: "missing repo", job: TransferJob{Owner: "acme", Repo: "missing-repo"}, expectError: true},
+		{name: "missing repo scope", job: TransferJob{Owner: "acme", Repo: "no-scope-repo"}, expectError: true},
+		{
+			name:        "teamIDs without admin:org scope",
+			job:         TransferJob{Owner: "acme", Repo: "org-repo", TeamIDs: []int64{7}},
+			expectError: true,
+		},
+		{
+			name: "teamIDs with admin:org scope",
+			job:  TransferJob{Owner: "acme", Repo: "ok-repo", TeamIDs: []int64{7}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := transferer.Preflight(context.Background(), []TransferJob{tc.job})
+			if tc.expectError && err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestPreflightSkipsScopeCheckForGitHubAppAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/access_tokens"):
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"token": "installation-token", "expires_at": "2099-01-01T00:00:00Z"}`))
+		case strings.Contains(r.URL.Path, "missing-repo"):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			// GitHub App installation tokens never carry X-OAuth-Scopes, unlike
+			// preflightTestServer's fixtures for classic PAT/OAuth-App auth.
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	appProvider, err := NewGitHubAppProvider(server.URL, "app-id", "12345", []byte(testRSAPrivateKeyPEM), false)
+	if err != nil {
+		t.Fatalf("NewGitHubAppProvider() error = %v", err)
+	}
+
+	transferer := &Transferer{
+		Client:       AuthInjectingClient(resty.New(), appProvider),
+		AuthProvider: appProvider,
+		APIBaseURL:   server.URL,
+	}
+
+	// GitHub App installation tokens never carry X-OAuth-Scopes, so a repo
+	// that would fail the scope check under a classic PAT (no-scope-repo has
+	// no "repo" scope, org-repo with TeamIDs has no "admin:org" scope) must
+	// still pass preflight when authenticated as a GitHub App.
+	tests := []TransferJob{
+		{Owner: "acme", Repo: "no-scope-repo"},
+		{Owner: "acme", Repo: "org-repo", TeamIDs: []int64{7}},
+	}
+	for _, job := range tests {
+		if err := transferer.Preflight(context.Background(), []TransferJob{job}); err != nil {
+			t.Errorf("Preflight(%+v) = %v, want nil", job, err)
+		}
+	}
+
+	// A genuinely missing repository is still caught regardless of auth mode.
+	if err := transferer.Preflight(context.Background(), []TransferJob{{Owner: "acme", Repo: "missing-repo"}}); err == nil {
+		t.Error("expected an error for a missing repository, got nil")
+	}
+}
+
+func TestPreflightJoinsErrorsAcrossJobs(t *testing.T) {
+	server := preflightTestServer(t)
+	defer server.Close()
+
+	transferer := &Transferer{
+		Client:       AuthInjectingClient(resty.New(), NewStaticTokenProvider("test-token")),
+		AuthProvider: NewStaticTokenProvider("test-token"),
+		APIBaseURL:   server.URL,
+	}
+
+	jobs := []TransferJob{
+		{Owner: "acme", Repo: "missing-repo"},
+		{Owner: "acme", Repo: "no-scope-repo"},
+	}
+
+	err := transferer.Preflight(context.Background(), jobs)
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	if !strings.Contains(err.Error(), "missing-repo") || !strings.Contains(err.Error(), "no-scope-repo") {
+		t.Errorf("expected joined error to mention both failing repos, got: %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package transfer
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// Reporter renders a batch of TransferResults for consumption by a human or
+// by a CI pipeline.
+type Reporter interface {
+	Report(w io.Writer, results []TransferResult) error
+}
+
+// TextReporter renders results as a human-readable summary, one line per
+// repository plus an aggregate count, matching the script's original output.
+type TextReporter struct{}
+
+// Report implements Reporter.
+func (TextReporter) Report(w io.Writer, results []TransferResult) error {
+	var successCount, deferredCount, failureCount int
+	for _, res := range results {
+		switch res.Status {
+		case StatusSuccess:
+			successCount++
+			fmt.Fprintf(w, "[SUCCESS] Repo %s: Transfer successful (%dms)\n", res.Repo, res.DurationMS)
+		case StatusDeferred:
+			deferredCount++
+			fmt.Fprintf(w, "[DEFERRED] Repo %s: %v\n", res.Repo, res.Err)
+		default:
+			failureCount++
+			fmt.Fprintf(w, "[FAIL] Repo %s: %v\n", res.Repo, res.Err)
+		}
+	}
+	_, err := fmt.Fprintf(w, "--- Summary: %d total, %d succeeded, %d rate-limited/deferred, %d failed ---\n",
+		len(results), successCount, deferredCount, failureCount)
+	return err
+}
+
+// jsonResult is the JSON Lines representation of a TransferResult; Err is
+// flattened to its message since errors don't marshal to JSON themselves.
+type jsonResult struct {
+	Repo       string `json:"repo"`
+	Status     Status `json:"status"`
+	HTTPCode   int    `json:"httpCode,omitempty"`
+	Err        string `json:"error,omitempty"`
+	DurationMS int64  `json:"durationMs"`
+	Attempts   int    `json:"attempts,omitempty"`
+}
+
+// JSONLReporter renders results as newline-delimited JSON, one object per
+// repository, for machine consumption (log pipelines, dashboards).
+type JSONLReporter struct{}
+
+// Report implements Reporter.
+func (JSONLReporter) Report(w io.Writer, results []TransferResult) error {
+	enc := json.NewEncoder(w)
+	for _, res := range results {
+		entry := jsonResult{
+			Repo:       res.Repo,
+			Status:     res.Status,
+			HTTPCode:   res.HTTPCode,
+			DurationMS: res.DurationMS,
+			Attempts:   res.Attempts,
+		}
+		if res.Err != nil {
+			entry.Err = res.Err.Error()
+		}
+		if err := enc.Encode(entry); err != nil {
+			return fmt.Errorf("jsonl reporter: failed to encode result for repo %s: %w", res.Repo, err)
+		}
+	}
+	return nil
+}
+
+// junitTestSuite and junitTestCase model the minimal JUnit XML schema CI
+// systems (GitHub Actions, Jenkins, etc.) expect for test result ingestion.
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name    string        `xml:"name,attr"`
+	TimeMS  float64       `xml:"time,attr"`
+	Failure *junitFailure `xml:"failure,omitempty"`
+	Skipped *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// JUnitReporter renders results as JUnit XML, treating deferred (rate-limited)
+// transfers as skipped rather than failed so CI dashboards don't flag them as
+// regressions.
+type JUnitReporter struct{}
+
+// Report implements Reporter.
+func (JUnitReporter) Report(w io.Writer, results []TransferResult) error {
+	suite := junitTestSuite{
+		Name:      "repo-transfer",
+		Tests:     len(results),
+		TestCases: make([]junitTestCase, 0, len(results)),
+	}
+
+	for _, res := range results {
+		tc := junitTestCase{
+			Name:   res.Repo,
+			TimeMS: float64(res.DurationMS) / 1000,
+		}
+		switch res.Status {
+		case StatusFailed:
+			suite.Failures++
+			tc.Failure = &junitFailure{Message: res.Err.Error()}
+		case StatusDeferred:
+			suite.Skipped++
+			tc.Skipped = &junitSkipped{Message: res.Err.Error()}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("junit reporter: failed to write XML header: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suite); err != nil {
+		return fmt.Errorf("junit reporter: failed to encode test suite: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,73 @@
+package transfer
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func sampleResults() []TransferResult {
+	return []TransferResult{
+		{Repo: "ok-repo", Status: StatusSuccess, HTTPCode: 202, DurationMS: 120},
+		{Repo: "limited-repo", Status: StatusDeferred, HTTPCode: 403, Err: errors.New("rate limited"), DurationMS: 50},
+		{Repo: "broken-repo", Status: StatusFailed, HTTPCode: 422, Err: errors.New("validation failed"), DurationMS: 80},
+	}
+}
+
+func TestTextReporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (TextReporter{}).Report(&buf, sampleResults()); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"[SUCCESS] Repo ok-repo", "[DEFERRED] Repo limited-repo", "[FAIL] Repo broken-repo", "3 total, 1 succeeded, 1 rate-limited/deferred, 1 failed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestJSONLReporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (JSONLReporter{}).Report(&buf, sampleResults()); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 JSON lines, got %d", len(lines))
+	}
+
+	var first jsonResult
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("failed to unmarshal first line: %v", err)
+	}
+	if first.Repo != "ok-repo" || first.Status != StatusSuccess {
+		t.Errorf("first line = %+v, want repo=ok-repo status=success", first)
+	}
+}
+
+func TestJUnitReporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (JUnitReporter{}).Report(&buf, sampleResults()); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	var suite junitTestSuite
+	if err := xml.Unmarshal(buf.Bytes(), &suite); err != nil {
+		t.Fatalf("failed to unmarshal JUnit XML: %v", err)
+	}
+	if suite.Tests != 3 {
+		t.Errorf("Tests = %d, want 3", suite.Tests)
+	}
+	if suite.Failures != 1 {
+		t.Errorf("Failures = %d, want 1", suite.Failures)
+	}
+	if suite.Skipped != 1 {
+		t.Errorf("Skipped = %d, want 1", suite.Skipped)
+	}
+}
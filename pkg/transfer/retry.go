@@ -0,0 +1,130 @@
+package transfer
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// RateLimitError indicates a request was deferred because of a GitHub rate
+// limit (primary or secondary) rather than a genuine permission or semantic
+// failure. Callers can distinguish it from other errors with errors.As.
+type RateLimitError struct {
+	Repo       string
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("repo %s: rate-limited, next attempt available in %s: %v", e.Repo, e.RetryAfter, e.Err)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// maxBackoffRetries bounds the exponential backoff applied to 5xx responses.
+const maxBackoffRetries = 5
+
+// ConfigureRetryPolicy wires GitHub-aware retry behavior onto client: 5xx
+// responses get exponential backoff with jitter, while 403/429 rate limits
+// wait exactly as long as GitHub's Retry-After or X-RateLimit-Reset headers
+// say to. Genuine 403 permission errors (no rate-limit headers) are not retried.
+func ConfigureRetryPolicy(client *resty.Client) *resty.Client {
+	client.SetRetryCount(maxBackoffRetries)
+
+	client.AddRetryCondition(func(res *resty.Response, err error) bool {
+		if err != nil {
+			return true // network-level error, safe to retry
+		}
+		if res.StatusCode() >= 500 {
+			return true
+		}
+		return isRateLimited(res)
+	})
+
+	client.SetRetryAfter(func(_ *resty.Client, res *resty.Response) (time.Duration, error) {
+		if res == nil {
+			return backoffWithJitter(0), nil
+		}
+		if isRateLimited(res) {
+			return rateLimitDelay(res), nil
+		}
+		return backoffWithJitter(res.Request.Attempt), nil
+	})
+
+	return client
+}
+
+// isRateLimited reports whether res represents a GitHub rate limit (primary
+// or secondary) rather than a genuine permission failure: GitHub always
+// attaches Retry-After or X-RateLimit-Remaining: 0 to rate-limit responses.
+func isRateLimited(res *resty.Response) bool {
+	if res.StatusCode() == http.StatusTooManyRequests {
+		return true
+	}
+	if res.StatusCode() != http.StatusForbidden {
+		return false
+	}
+	if res.Header().Get("Retry-After") != "" {
+		return true
+	}
+	return res.Header().Get("X-RateLimit-Remaining") == "0"
+}
+
+// rateLimitDelay computes how long to wait before retrying a rate-limited
+// response: Retry-After (seconds or HTTP-date) takes priority, falling back
+// to sleeping until X-RateLimit-Reset (a Unix timestamp).
+func rateLimitDelay(res *resty.Response) time.Duration {
+	if retryAfter := res.Header().Get("Retry-After"); retryAfter != "" {
+		if d, err := parseRetryAfter(retryAfter); err == nil {
+			return d
+		}
+		log.Warnf("Failed to parse Retry-After header %q, falling back to X-RateLimit-Reset", retryAfter)
+	}
+
+	if reset := res.Header().Get("X-RateLimit-Reset"); reset != "" {
+		if unixSeconds, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			delay := time.Until(time.Unix(unixSeconds, 0))
+			if delay > 0 {
+				return delay
+			}
+		}
+	}
+
+	return backoffWithJitter(0)
+}
+
+// parseRetryAfter parses a Retry-After header, which per RFC 9110 is either
+// a number of seconds or an HTTP-date.
+func parseRetryAfter(value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		return time.Until(when), nil
+	}
+	return 0, fmt.Errorf("invalid Retry-After value %q", value)
+}
+
+// backoffWithJitter returns an exponential backoff delay for the given retry
+// attempt (1-indexed, per resty convention), capped and jittered by up to 50%
+// to avoid every worker retrying in lockstep.
+func backoffWithJitter(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	base := 2 * time.Second
+	delay := base << uint(attempt-1)
+	const maxDelay = 60 * time.Second
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay) / 2))
+	return delay/2 + jitter
+}
@@ -0,0 +1,88 @@
+package transfer
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// newFakeResponse builds a minimal *resty.Response for exercising header-driven
+// logic without making a real HTTP round trip.
+func newFakeResponse(statusCode int, headers map[string]string) *resty.Response {
+	h := make(http.Header, len(headers))
+	for k, v := range headers {
+		h.Set(k, v)
+	}
+	return &resty.Response{
+		RawResponse: &http.Response{
+			StatusCode: statusCode,
+			Header:     h,
+		},
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		wantSeconds float64
+		expectError bool
+	}{
+		{name: "seconds", value: "30", wantSeconds: 30},
+		{name: "HTTP-date", value: time.Now().Add(45 * time.Second).UTC().Format(http.TimeFormat), wantSeconds: 45},
+		{name: "garbage", value: "not-a-delay", expectError: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseRetryAfter(tc.value)
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("expected an error for %q, got nil", tc.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := got.Seconds() - tc.wantSeconds; diff < -1 || diff > 1 {
+				t.Errorf("parseRetryAfter(%q) = %v, want ~%vs", tc.value, got, tc.wantSeconds)
+			}
+		})
+	}
+}
+
+func TestIsRateLimited(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		headers    map[string]string
+		want       bool
+	}{
+		{name: "429 always rate limited", statusCode: http.StatusTooManyRequests, want: true},
+		{name: "403 with Retry-After is rate limited", statusCode: http.StatusForbidden, headers: map[string]string{"Retry-After": "5"}, want: true},
+		{name: "403 with remaining 0 is rate limited", statusCode: http.StatusForbidden, headers: map[string]string{"X-RateLimit-Remaining": "0"}, want: true},
+		{name: "403 with neither header is a permission error", statusCode: http.StatusForbidden, want: false},
+		{name: "404 is never rate limited", statusCode: http.StatusNotFound, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := newFakeResponse(tc.statusCode, tc.headers)
+			if got := isRateLimited(res); got != tc.want {
+				t.Errorf("isRateLimited() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBackoffWithJitterStaysWithinBounds(t *testing.T) {
+	for attempt := 1; attempt <= maxBackoffRetries; attempt++ {
+		d := backoffWithJitter(attempt)
+		if d <= 0 || d > 60*time.Second {
+			t.Errorf("backoffWithJitter(%d) = %v, want in (0, 60s]", attempt, d)
+		}
+	}
+}
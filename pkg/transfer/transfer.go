@@ -0,0 +1,398 @@
+// Package transfer implements bulk GitHub repository ownership transfers
+// with bounded concurrency, pluggable authentication, rate-limit-aware
+// retries, and pluggable result reporting.
+package transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultAPIBaseURL is used when no GitHub Enterprise base URL is configured.
+const DefaultAPIBaseURL = "https://api.github.com"
+
+// TransferJob describes a single repository transfer to perform.
+type TransferJob struct {
+	Owner    string
+	Repo     string
+	NewOwner string
+	// NewName renames the repository during transfer. Leave empty to keep
+	// the destination name identical to Repo.
+	NewName string
+	// TeamIDs grants the listed organization teams access to the repository
+	// at the destination, when transferring into an organization.
+	TeamIDs []int64
+}
+
+// destinationName returns the name the repository should have after transfer.
+func (j TransferJob) destinationName() string {
+	if j.NewName != "" {
+		return j.NewName
+	}
+	return j.Repo
+}
+
+// Status classifies how a TransferJob attempt concluded.
+type Status string
+
+const (
+	StatusSuccess  Status = "success"
+	StatusDeferred Status = "deferred" // rate-limited; caller may retry later
+	StatusFailed   Status = "failed"
+)
+
+// TransferResult reports the outcome of attempting a single TransferJob.
+type TransferResult struct {
+	Repo string
+	// Status classifies how the attempt concluded.
+	Status Status
+	// HTTPCode is the status code of the transfer POST response (zero if the
+	// request never reached GitHub, e.g. a network error).
+	HTTPCode int
+	Err      error
+	// DurationMS is the total wall-clock time spent on this job, including
+	// any completion polling (see Transferer.WaitForCompletion).
+	DurationMS int64
+	// Attempts is the total number of HTTP requests made for this job: the
+	// transfer POST's own retry count (see ConfigureRetryPolicy) plus, when
+	// Transferer.WaitForCompletion is set, the completion-poll GETs.
+	Attempts int
+}
+
+// Transferer runs a set of repository transfers with bounded concurrency.
+type Transferer struct {
+	Client       *resty.Client
+	AuthProvider AuthProvider
+	// APIBaseURL defaults to DefaultAPIBaseURL when empty.
+	APIBaseURL string
+	// Workers is the number of concurrent transfer workers. Defaults to 1.
+	Workers int
+	// PerRepoTimeout bounds each individual transfer request. Zero means no timeout.
+	PerRepoTimeout time.Duration
+	// WaitForCompletion polls the destination repository after a 202 response
+	// until it becomes reachable, to catch transfers GitHub accepted but
+	// never actually completed (e.g. an org policy rejected it asynchronously).
+	WaitForCompletion bool
+	// PollTimeout bounds how long to wait for a transferred repo to become
+	// reachable. Defaults to defaultPollTimeout when WaitForCompletion is set
+	// and this is left zero.
+	PollTimeout time.Duration
+}
+
+// defaultPollTimeout is used when WaitForCompletion is set and PollTimeout is left zero.
+const defaultPollTimeout = 2 * time.Minute
+
+// TransferUnreachableError indicates a transfer was accepted (HTTP 202) but
+// its destination repository never became reachable before PollTimeout
+// elapsed, suggesting the asynchronous move silently failed.
+type TransferUnreachableError struct {
+	Repo        string
+	Owner       string
+	Destination string
+	Waited      time.Duration
+	Err         error
+}
+
+func (e *TransferUnreachableError) Error() string {
+	return fmt.Sprintf("repo %s: accepted but destination %s/%s never became reachable after %s: %v",
+		e.Repo, e.Owner, e.Destination, e.Waited, e.Err)
+}
+
+func (e *TransferUnreachableError) Unwrap() error {
+	return e.Err
+}
+
+// ReconciliationSummary returns one line per result whose destination never
+// became reachable after a 202 was received, so an operator can see at a
+// glance which accepted transfers still need manual follow-up.
+func ReconciliationSummary(results []TransferResult) []string {
+	var lines []string
+	for _, res := range results {
+		var unreachable *TransferUnreachableError
+		if !errors.As(res.Err, &unreachable) {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf(
+			"repo %s: accepted but never became reachable at %s/%s after %s (%d poll attempts)",
+			res.Repo, unreachable.Owner, unreachable.Destination, unreachable.Waited, res.Attempts))
+	}
+	return lines
+}
+
+// Run executes all jobs with t.Workers concurrent workers, stopping early if
+// ctx is canceled (e.g. on SIGINT). Each job gets its own PerRepoTimeout-bound
+// sub-context. Results are returned in completion order, not job order.
+func (t *Transferer) Run(ctx context.Context, jobs []TransferJob) []TransferResult {
+	workers := t.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobCh := make(chan TransferJob, len(jobs))
+	resultCh := make(chan TransferResult, len(jobs))
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(workerID int) {
+			defer wg.Done()
+			workerLog := log.WithField("workerID", workerID)
+			for job := range jobCh {
+				select {
+				case <-ctx.Done():
+					workerLog.WithField("repo", job.Repo).Warn("Skipping transfer: context canceled")
+					resultCh <- TransferResult{Repo: job.Repo, Status: StatusFailed, Err: ctx.Err()}
+					continue
+				default:
+				}
+				resultCh <- t.transferOne(ctx, job)
+			}
+		}(w)
+	}
+
+	for _, job := range jobs {
+		jobCh <- job
+	}
+	close(jobCh)
+
+	go func() {
+		wg.Wait()
+		close(resultCh)
+	}()
+
+	results := make([]TransferResult, 0, len(jobs))
+	for res := range resultCh {
+		results = append(results, res)
+	}
+	return results
+}
+
+// transferOne performs a single repository transfer and times it, classifying
+// the outcome as success, rate-limit deferral, or failure.
+func (t *Transferer) transferOne(ctx context.Context, job TransferJob) TransferResult {
+	repoLog := log.WithFields(log.Fields{"repo": job.Repo, "newOwner": job.NewOwner})
+	repoLog.Info("Processing repository transfer")
+
+	reqCtx := ctx
+	if t.PerRepoTimeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(ctx, t.PerRepoTimeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	outcome, err := t.transferRepo(reqCtx, job)
+	result := TransferResult{
+		Repo:       job.Repo,
+		HTTPCode:   outcome.httpCode,
+		DurationMS: time.Since(start).Milliseconds(),
+		Attempts:   outcome.attempts,
+	}
+
+	var rateLimitErr *RateLimitError
+	switch {
+	case err == nil:
+		result.Status = StatusSuccess
+		repoLog.Info("Repository transfer attempt successful")
+	case errors.As(err, &rateLimitErr):
+		result.Status = StatusDeferred
+		result.Err = err
+		repoLog.WithError(err).Warn("Repository transfer deferred due to rate limiting")
+	default:
+		result.Status = StatusFailed
+		result.Err = err
+		repoLog.WithError(err).Error("Repository transfer attempt failed")
+	}
+	return result
+}
+
+// transferOutcome carries the parts of a transferRepo attempt that transferOne
+// folds into the TransferResult, beyond the error itself.
+type transferOutcome struct {
+	// httpCode is the status code of the transfer POST response (zero if the
+	// request never reached GitHub, e.g. a network error).
+	httpCode int
+	// attempts is the transfer POST's own retry count plus, for jobs with
+	// WaitForCompletion set, the completion-poll GETs performed.
+	attempts int
+}
+
+// transferRepo issues the transfer request for a single job and classifies
+// the response. Authentication is handled by t.AuthProvider and injected onto
+// the request by the shared client's OnBeforeRequest hook (see AuthInjectingClient).
+func (t *Transferer) transferRepo(ctx context.Context, job TransferJob) (transferOutcome, error) {
+	if _, err := t.AuthProvider.Token(ctx); err != nil {
+		return transferOutcome{}, fmt.Errorf("repo %s: failed to obtain auth token: %w", job.Repo, err)
+	}
+
+	apiBaseURL := t.APIBaseURL
+	if apiBaseURL == "" {
+		apiBaseURL = DefaultAPIBaseURL
+	}
+	transferURL := BuildTransferURL(apiBaseURL, job.Owner, job.Repo)
+
+	body := map[string]interface{}{
+		"new_owner": job.NewOwner,
+		"new_name":  job.destinationName(),
+	}
+	if len(job.TeamIDs) > 0 {
+		body["team_ids"] = job.TeamIDs
+	}
+
+	log.WithFields(log.Fields{
+		"repo":     job.Repo,
+		"newOwner": job.NewOwner,
+		"url":      transferURL,
+	}).Info("Attempting to transfer repository")
+
+	res, err := t.Client.R().
+		SetContext(ctx).
+		SetBody(body).
+		SetHeader("Accept", "application/vnd.github+json").
+		SetHeader("X-GitHub-Api-Version", "2022-11-28").
+		Post(transferURL)
+
+	if err != nil {
+		// This error is from the client (e.g., network issue)
+		return transferOutcome{}, fmt.Errorf("client request for repo %s failed: %w", job.Repo, err)
+	}
+
+	outcome := transferOutcome{httpCode: res.StatusCode(), attempts: res.Request.Attempt}
+
+	logFields := log.Fields{
+		"repo":       job.Repo,
+		"statusCode": res.StatusCode(),
+		"status":     res.Status(),
+	}
+	if res.StatusCode() != 202 { // Log body for non-successful transfers for debugging
+		logFields["responseBody"] = res.String()
+	}
+
+	switch res.StatusCode() {
+	case 202: // Accepted
+		log.WithFields(logFields).Info("Repository transfer accepted")
+		if !t.WaitForCompletion {
+			return outcome, nil
+		}
+		pollAttempts, err := t.pollForCompletion(ctx, apiBaseURL, job)
+		outcome.attempts += pollAttempts
+		if err != nil {
+			log.WithFields(logFields).WithError(err).Warn("Repository transfer accepted but destination never became reachable")
+			return outcome, err
+		}
+		log.WithFields(logFields).Info("Repository transfer confirmed reachable at destination")
+		return outcome, nil
+	case 401: // Unauthorized
+		log.WithFields(logFields).Error("Repository transfer failed: Unauthorized")
+		return outcome, fmt.Errorf("repo %s: Unauthorized (HTTP %d). Check GitHub token and permissions. Response: %s", job.Repo, res.StatusCode(), res.String())
+	case 403, 429: // Forbidden or Too Many Requests
+		if isRateLimited(res) {
+			log.WithFields(logFields).Warn("Repository transfer deferred: rate-limited")
+			return outcome, &RateLimitError{
+				Repo:       job.Repo,
+				RetryAfter: rateLimitDelay(res),
+				Err:        fmt.Errorf("repo %s: rate limited (HTTP %d). Response: %s", job.Repo, res.StatusCode(), res.String()),
+			}
+		}
+		log.WithFields(logFields).Error("Repository transfer failed: Forbidden")
+		return outcome, fmt.Errorf("repo %s: Forbidden (HTTP %d). Insufficient permissions. Response: %s", job.Repo, res.StatusCode(), res.String())
+	case 404: // Not Found
+		log.WithFields(logFields).Error("Repository transfer failed: Not Found")
+		return outcome, fmt.Errorf("repo %s: Repository or user not found (HTTP %d). Response: %s", job.Repo, res.StatusCode(), res.String())
+	case 422: // Unprocessable Entity
+		log.WithFields(logFields).Error("Repository transfer failed: Unprocessable Entity")
+		return outcome, fmt.Errorf("repo %s: Unprocessable Entity (HTTP %d). Semantic errors. Response: %s", job.Repo, res.StatusCode(), res.String())
+	default:
+		log.WithFields(logFields).Error("Repository transfer failed: Unexpected status code")
+		return outcome, fmt.Errorf("repo %s: Unexpected status code (HTTP %d). Response: %s", job.Repo, res.StatusCode(), res.String())
+	}
+}
+
+// pollForCompletion polls the destination repository after a 202 response,
+// backing off exponentially between attempts, until it returns HTTP 200 (the
+// move completed) or t.PollTimeout elapses (continued 404 past the deadline
+// is treated as failure: the move likely never completed). It returns the
+// number of polls performed.
+func (t *Transferer) pollForCompletion(ctx context.Context, apiBaseURL string, job TransferJob) (int, error) {
+	timeout := t.PollTimeout
+	if timeout <= 0 {
+		timeout = defaultPollTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	destURL := fmt.Sprintf("%s/repos/%s/%s", apiBaseURL, job.NewOwner, job.destinationName())
+
+	var lastErr error
+	attempts := 0
+	for {
+		attempts++
+		res, err := t.Client.R().
+			SetContext(ctx).
+			SetHeader("Accept", "application/vnd.github+json").
+			SetHeader("X-GitHub-Api-Version", "2022-11-28").
+			Get(destURL)
+		switch {
+		case err != nil:
+			lastErr = err
+		case res.StatusCode() == 200:
+			return attempts, nil
+		case res.StatusCode() == 404:
+			lastErr = fmt.Errorf("destination %s/%s not yet reachable (HTTP 404)", job.NewOwner, job.destinationName())
+		default:
+			lastErr = fmt.Errorf("unexpected status polling destination %s/%s (HTTP %d)", job.NewOwner, job.destinationName(), res.StatusCode())
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return attempts, &TransferUnreachableError{
+				Repo:        job.Repo,
+				Owner:       job.NewOwner,
+				Destination: job.destinationName(),
+				Waited:      timeout,
+				Err:         lastErr,
+			}
+		}
+
+		delay := backoffWithJitter(attempts)
+		if delay > remaining {
+			delay = remaining
+		}
+		select {
+		case <-ctx.Done():
+			return attempts, fmt.Errorf("repo %s: context canceled while waiting for transfer completion: %w", job.Repo, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// ValidateAPIBaseURL ensures the configured API base URL is well-formed and usable
+// as the root of REST requests (absolute, http/https, no trailing slash).
+func ValidateAPIBaseURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("failed to parse URL %q: %w", raw, err)
+	}
+	if !parsed.IsAbs() || parsed.Host == "" {
+		return fmt.Errorf("URL %q must be absolute (e.g. https://api.github.com)", raw)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("URL %q must use http or https, got scheme %q", raw, parsed.Scheme)
+	}
+	if strings.HasSuffix(raw, "/") {
+		return fmt.Errorf("URL %q must not have a trailing slash", raw)
+	}
+	return nil
+}
+
+// BuildTransferURL constructs the repo transfer endpoint for a given API base,
+// owner and repo, so enterprise installations can be targeted alongside github.com.
+func BuildTransferURL(base, owner, repo string) string {
+	return fmt.Sprintf("%s/repos/%s/%s/transfer", base, owner, repo)
+}
@@ -0,0 +1,207 @@
+package transfer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func TestBuildTransferURL(t *testing.T) {
+	got := BuildTransferURL("https://github.example.com/api/v3", "octo-org", "octo-repo")
+	want := "https://github.example.com/api/v3/repos/octo-org/octo-repo/transfer"
+	if got != want {
+		t.Errorf("BuildTransferURL() = %q, want %q", got, want)
+	}
+}
+
+func TestValidateAPIBaseURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		expectError bool
+	}{
+		{name: "default github.com", url: "https://api.github.com", expectError: false},
+		{name: "GHES instance", url: "https://github.example.com/api/v3", expectError: false},
+		{name: "trailing slash", url: "https://api.github.com/", expectError: true},
+		{name: "missing scheme", url: "api.github.com", expectError: true},
+		{name: "unsupported scheme", url: "ftp://api.github.com", expectError: true},
+		{name: "not a URL", url: "::not a url::", expectError: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateAPIBaseURL(tc.url)
+			if tc.expectError && err == nil {
+				t.Errorf("expected an error for %q, got nil", tc.url)
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("expected no error for %q, got %v", tc.url, err)
+			}
+		})
+	}
+}
+
+func TestTransfererRunClassifiesResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "ok-repo"):
+			w.WriteHeader(http.StatusAccepted)
+		case strings.Contains(r.URL.Path, "limited-repo"):
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	transferer := &Transferer{
+		Client:       AuthInjectingClient(resty.New(), NewStaticTokenProvider("test-token")),
+		AuthProvider: NewStaticTokenProvider("test-token"),
+		APIBaseURL:   server.URL,
+		Workers:      2,
+	}
+
+	jobs := []TransferJob{
+		{Owner: "acme", Repo: "ok-repo", NewOwner: "acme-new"},
+		{Owner: "acme", Repo: "limited-repo", NewOwner: "acme-new"},
+		{Owner: "acme", Repo: "missing-repo", NewOwner: "acme-new"},
+	}
+
+	results := transferer.Run(context.Background(), jobs)
+	if len(results) != len(jobs) {
+		t.Fatalf("expected %d results, got %d", len(jobs), len(results))
+	}
+
+	byRepo := make(map[string]TransferResult, len(results))
+	for _, res := range results {
+		byRepo[res.Repo] = res
+	}
+
+	if got := byRepo["ok-repo"].Status; got != StatusSuccess {
+		t.Errorf("ok-repo status = %v, want %v", got, StatusSuccess)
+	}
+	if got := byRepo["limited-repo"].Status; got != StatusDeferred {
+		t.Errorf("limited-repo status = %v, want %v", got, StatusDeferred)
+	}
+	if got := byRepo["missing-repo"].Status; got != StatusFailed {
+		t.Errorf("missing-repo status = %v, want %v", got, StatusFailed)
+	}
+
+	if got := byRepo["ok-repo"].HTTPCode; got != http.StatusAccepted {
+		t.Errorf("ok-repo HTTPCode = %d, want %d", got, http.StatusAccepted)
+	}
+	if got := byRepo["missing-repo"].HTTPCode; got != http.StatusNotFound {
+		t.Errorf("missing-repo HTTPCode = %d, want %d", got, http.StatusNotFound)
+	}
+}
+
+func TestTransfererRunRespectsContextCancellation(t *testing.T) {
+	transferer := &Transferer{
+		Client:       resty.New(),
+		AuthProvider: NewStaticTokenProvider("test-token"),
+		Workers:      1,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	jobs := []TransferJob{{Owner: "acme", Repo: "whatever", NewOwner: "acme-new"}}
+	results := transferer.Run(ctx, jobs)
+	if len(results) != 1 || results[0].Status != StatusFailed {
+		t.Fatalf("expected a single failed result for a canceled context, got %+v", results)
+	}
+}
+
+func TestTransfererRunWaitsForCompletion(t *testing.T) {
+	var pollCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/transfer"):
+			w.WriteHeader(http.StatusAccepted)
+		case strings.Contains(r.URL.Path, "slow-repo"):
+			if atomic.AddInt32(&pollCount, 1) < 2 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	transferer := &Transferer{
+		Client:            AuthInjectingClient(resty.New(), NewStaticTokenProvider("test-token")),
+		AuthProvider:      NewStaticTokenProvider("test-token"),
+		APIBaseURL:        server.URL,
+		Workers:           1,
+		WaitForCompletion: true,
+		PollTimeout:       5 * time.Second,
+	}
+
+	jobs := []TransferJob{{Owner: "acme", Repo: "slow-repo", NewOwner: "acme-new"}}
+	results := transferer.Run(context.Background(), jobs)
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Status != StatusSuccess {
+		t.Fatalf("status = %v, want %v (err: %v)", results[0].Status, StatusSuccess, results[0].Err)
+	}
+	if results[0].Attempts < 2 {
+		t.Errorf("Attempts = %d, want at least 2 poll attempts", results[0].Attempts)
+	}
+}
+
+func TestTransfererRunReportsUnreachableDestination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/transfer"):
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	transferer := &Transferer{
+		Client:            AuthInjectingClient(resty.New(), NewStaticTokenProvider("test-token")),
+		AuthProvider:      NewStaticTokenProvider("test-token"),
+		APIBaseURL:        server.URL,
+		Workers:           1,
+		WaitForCompletion: true,
+		PollTimeout:       10 * time.Millisecond,
+	}
+
+	jobs := []TransferJob{{Owner: "acme", Repo: "never-arrives", NewOwner: "acme-new"}}
+	results := transferer.Run(context.Background(), jobs)
+	if len(results) != 1 || results[0].Status != StatusFailed {
+		t.Fatalf("expected a single failed result, got %+v", results)
+	}
+
+	lines := ReconciliationSummary(results)
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 reconciliation line, got %d: %v", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], "never-arrives") {
+		t.Errorf("reconciliation line = %q, want it to mention never-arrives", lines[0])
+	}
+}
+
+func TestTransferJobDestinationName(t *testing.T) {
+	withoutRename := TransferJob{Repo: "source-repo"}
+	if got := withoutRename.destinationName(); got != "source-repo" {
+		t.Errorf("destinationName() = %q, want %q", got, "source-repo")
+	}
+
+	withRename := TransferJob{Repo: "source-repo", NewName: "renamed-repo"}
+	if got := withRename.destinationName(); got != "renamed-repo" {
+		t.Errorf("destinationName() = %q, want %q", got, "renamed-repo")
+	}
+}